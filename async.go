@@ -0,0 +1,176 @@
+package noodlog
+
+import (
+	"sync"
+	"time"
+)
+
+// OverflowPolicy controls what happens when an async Handler's buffer is full and a new record
+// arrives.
+type OverflowPolicy int
+
+const (
+	// Block waits for room in the buffer, applying backpressure to the caller.
+	Block OverflowPolicy = iota
+	// DropOldest discards the oldest buffered record to make room for the new one.
+	DropOldest
+	// DropNewest discards the incoming record, leaving the buffer as it is.
+	DropNewest
+)
+
+// asyncHandler wraps another Handler and writes to it from a single background goroutine, batching
+// records and flushing on a size or time threshold instead of paying JSON marshal + syscall cost on
+// every call. It is the implementation behind Configs.AsyncBufferSize / Configs.FlushInterval.
+type asyncHandler struct {
+	inner      Handler
+	bufferSize int
+	records    chan record
+	overflow   OverflowPolicy
+	flushReq   chan chan struct{}
+	done       chan struct{}
+	closeOnce  sync.Once
+	wg         sync.WaitGroup
+}
+
+// newAsyncHandler starts the background flush loop for inner, batching up to bufferSize records
+// and flushing at least every flushInterval.
+func newAsyncHandler(inner Handler, bufferSize int, flushInterval time.Duration, overflow OverflowPolicy) *asyncHandler {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	h := &asyncHandler{
+		inner:      inner,
+		bufferSize: bufferSize,
+		records:    make(chan record, bufferSize),
+		overflow:   overflow,
+		flushReq:   make(chan chan struct{}),
+		done:       make(chan struct{}),
+	}
+	h.wg.Add(1)
+	go h.loop(flushInterval)
+	return h
+}
+
+func (h *asyncHandler) Enabled(level string) bool {
+	return h.inner.Enabled(level)
+}
+
+func (h *asyncHandler) Handle(rec record) error {
+	select {
+	case h.records <- rec:
+		return nil
+	default:
+	}
+
+	switch h.overflow {
+	case DropNewest:
+		return nil
+	case DropOldest:
+		select {
+		case <-h.records:
+		default:
+		}
+		select {
+		case h.records <- rec:
+		default:
+		}
+		return nil
+	default: // Block
+		select {
+		case h.records <- rec:
+		case <-h.done:
+			// Close ran concurrently and the loop has stopped reading; drop rec instead of
+			// blocking forever on a channel nothing will ever drain again.
+		}
+		return nil
+	}
+}
+
+func (h *asyncHandler) loop(flushInterval time.Duration) {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]record, 0, h.bufferSize)
+	flushBatch := func() {
+		for _, rec := range batch {
+			_ = h.inner.Handle(rec)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case rec := <-h.records:
+			batch = append(batch, rec)
+			if len(batch) >= h.bufferSize {
+				flushBatch()
+			}
+		case <-ticker.C:
+			flushBatch()
+		case ack := <-h.flushReq:
+			h.drain(&batch)
+			flushBatch()
+			ack <- struct{}{}
+		case <-h.done:
+			h.drain(&batch)
+			flushBatch()
+			return
+		}
+	}
+}
+
+// drain moves any records still sitting in the channel into batch without blocking.
+func (h *asyncHandler) drain(batch *[]record) {
+	for {
+		select {
+		case rec := <-h.records:
+			*batch = append(*batch, rec)
+		default:
+			return
+		}
+	}
+}
+
+// flush synchronously writes every record currently queued, including whatever the background loop
+// has already pulled into its in-flight batch, blocking until the loop goroutine has handed it all
+// to the inner Handler. It asks the loop itself to flush rather than draining h.records from a
+// second goroutine, since records sitting in the loop's batch aren't visible on that channel.
+func (h *asyncHandler) flush() {
+	ack := make(chan struct{})
+	select {
+	case h.flushReq <- ack:
+		<-ack
+	case <-h.done:
+		// The loop has already stopped and flushed everything on its way out.
+	}
+}
+
+// close stops the background flush loop after writing any buffered records. It is safe to call
+// more than once; only the first call has any effect.
+func (h *asyncHandler) close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+		h.wg.Wait()
+	})
+}
+
+// Flush blocks until every record currently buffered by an async Handler has been written. It is a
+// no-op when the active Handler isn't async.
+func Flush() {
+	if h, ok := handler.(*asyncHandler); ok {
+		h.flush()
+	}
+}
+
+// Close flushes and stops the active Handler's background goroutine, if it has one. Applications
+// should call Close during shutdown to guarantee buffered records are written before exiting.
+func Close() {
+	if h, ok := handler.(*asyncHandler); ok {
+		h.close()
+	}
+}
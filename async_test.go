@@ -0,0 +1,89 @@
+package noodlog
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingHandler struct {
+	mu   sync.Mutex
+	recs []record
+}
+
+func (h *recordingHandler) Enabled(string) bool { return true }
+
+func (h *recordingHandler) Handle(rec record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recs = append(h.recs, rec)
+	return nil
+}
+
+func (h *recordingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.recs)
+}
+
+func TestAsyncHandlerFlushWritesInFlightBatch(t *testing.T) {
+	inner := &recordingHandler{}
+	h := newAsyncHandler(inner, 100, time.Hour, Block)
+	defer h.close()
+
+	for i := 0; i < 5; i++ {
+		_ = h.Handle(record{Level: infoLabel, Message: i})
+	}
+
+	h.flush()
+
+	if got := inner.count(); got != 5 {
+		t.Fatalf("flush() wrote %d records, want 5", got)
+	}
+}
+
+func TestAsyncHandlerCloseIsIdempotent(t *testing.T) {
+	inner := &recordingHandler{}
+	h := newAsyncHandler(inner, 10, time.Hour, Block)
+
+	h.close()
+	h.close()
+}
+
+type blockingHandler struct{ block chan struct{} }
+
+func (h *blockingHandler) Enabled(string) bool { return true }
+
+func (h *blockingHandler) Handle(record) error {
+	<-h.block
+	return nil
+}
+
+func TestAsyncHandlerBlockHandleUnblocksOnClose(t *testing.T) {
+	block := make(chan struct{})
+	h := newAsyncHandler(&blockingHandler{block: block}, 1, time.Hour, Block)
+
+	// Consumed by the loop goroutine, which then blocks inside inner.Handle.
+	_ = h.Handle(record{Level: infoLabel, Message: 1})
+	time.Sleep(10 * time.Millisecond)
+	// Fills the buffer while the loop is stuck, so the next Handle has nowhere to go.
+	_ = h.Handle(record{Level: infoLabel, Message: 2})
+
+	handleDone := make(chan struct{})
+	go func() {
+		_ = h.Handle(record{Level: infoLabel, Message: 3})
+		close(handleDone)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	close(h.done)
+
+	select {
+	case <-handleDone:
+	case <-time.After(time.Second):
+		t.Fatal("Handle() did not return after Close unblocked it: goroutine leak")
+	}
+
+	close(block)
+	h.wg.Wait()
+}
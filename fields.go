@@ -0,0 +1,94 @@
+package noodlog
+
+import "os"
+
+// Logger carries a set of key/value fields that get merged into every record it emits, letting
+// callers propagate request-scoped context (request_id, user_id, trace_id) through a call chain
+// instead of repeating it in every log call.
+type Logger struct {
+	fields map[string]interface{}
+}
+
+// NewLogger returns a Logger with no accumulated fields.
+func NewLogger() *Logger {
+	return &Logger{}
+}
+
+// With returns a child Logger carrying key/value in addition to l's existing fields.
+func (l *Logger) With(key string, value interface{}) *Logger {
+	return l.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields returns a child Logger carrying fields in addition to l's existing fields. Keys in
+// fields take precedence over keys already present on l.
+func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Logger{fields: merged}
+}
+
+// WithError is a shortcut for With("error", err).
+func (l *Logger) WithError(err error) *Logger {
+	return l.With("error", err)
+}
+
+// Trace function prints a log with trace log level, merging in l's fields.
+func (l *Logger) Trace(message ...interface{}) {
+	l.printLog(traceLabel, message)
+}
+
+// Debug function prints a log with debug log level, merging in l's fields.
+func (l *Logger) Debug(message ...interface{}) {
+	l.printLog(debugLabel, message)
+}
+
+// Info function prints a log with info log level, merging in l's fields.
+func (l *Logger) Info(message ...interface{}) {
+	l.printLog(infoLabel, message)
+}
+
+// Warn function prints a log with warn log level, merging in l's fields.
+func (l *Logger) Warn(message ...interface{}) {
+	l.printLog(warnLabel, message)
+}
+
+// Error function prints a log with error log level, merging in l's fields.
+func (l *Logger) Error(message ...interface{}) {
+	l.printLog(errorLabel, message)
+}
+
+// Panic function prints a log with panic log level, merging in l's fields.
+func (l *Logger) Panic(message ...interface{}) {
+	rec := newRecord(panicLabel, message)
+	rec.Fields = l.fields
+	fireHooks(rec)
+	logRecord := formatRecord(rec)
+	Flush()
+	panic(logRecord)
+}
+
+// Fatal function prints a log with fatal log level, merging in l's fields.
+func (l *Logger) Fatal(message ...interface{}) {
+	l.printLog(fatalLabel, message)
+	Flush()
+	os.Exit(1)
+}
+
+func (l *Logger) printLog(label string, message []interface{}) {
+	handlerOK := handler.Enabled(label)
+	if !handlerOK && !hookInterested(label) {
+		return
+	}
+
+	rec := newRecord(label, message)
+	rec.Fields = l.fields
+	fireHooks(rec)
+	if handlerOK {
+		_ = handler.Handle(rec)
+	}
+}
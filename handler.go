@@ -0,0 +1,239 @@
+package noodlog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Handler formats and writes a log record to its destination. Implementations plug into a Logger
+// via SetConfigs(Configs{Handler: ...}), so the same log calls can be routed to structured JSON,
+// logfmt, or a human-readable terminal format depending on where they end up.
+type Handler interface {
+	// Handle formats rec and writes it to the handler's destination.
+	Handle(rec record) error
+	// Enabled reports whether a record at the given level should be passed to Handle.
+	Enabled(level string) bool
+}
+
+// HandlerOptions configures the behaviour common to all built-in Handler implementations.
+type HandlerOptions struct {
+	// Level is the minimum level this handler writes. An empty string defers to the package-level
+	// LogLevel, matching noodlog's previous behaviour.
+	Level string
+	// Colors forces coloring on or off for this handler, so e.g. a JSONHandler writing to a file can
+	// stay uncolored while a TerminalHandler on the same Logger still colors its output. Nil defers
+	// to the package-level colorEnabled for a JSONHandler and to terminal detection for a
+	// TerminalHandler. A LogfmtHandler never colors its output.
+	Colors *bool
+	// PrettyPrint overrides the package-level JSONPrettyPrint for a JSONHandler. Nil defers to
+	// JSONPrettyPrint. The other handlers ignore it.
+	PrettyPrint *bool
+}
+
+// handlerLevelEnabled applies opts.Level, falling back to the global logLevel when opts is nil or
+// opts.Level is unset, so handlers without explicit options keep filtering like LogLevel always has.
+// An opts.Level that LogLevel wouldn't recognize falls back to infoLevel too, for the same reason.
+// It resolves both labels through labelLevel's switch rather than a map lookup, so the comparison
+// it makes is a single integer compare once inlined.
+func handlerLevelEnabled(level string, opts *HandlerOptions) bool {
+	if opts == nil || opts.Level == "" {
+		return labelLevel(level) >= logLevel
+	}
+	return labelLevel(level) >= labelLevel(opts.Level)
+}
+
+// handlerColorsEnabled reports whether h's opts should color their output, falling back to the
+// package-level colorEnabled when opts is nil or doesn't override it.
+func handlerColorsEnabled(opts *HandlerOptions) bool {
+	if opts == nil || opts.Colors == nil {
+		return colorEnabled
+	}
+	return *opts.Colors
+}
+
+// handlerPrettyPrintEnabled reports whether h's opts should pretty-print JSON, falling back to the
+// package-level JSONPrettyPrint when opts is nil or doesn't override it.
+func handlerPrettyPrintEnabled(opts *HandlerOptions) bool {
+	if opts == nil || opts.PrettyPrint == nil {
+		return JSONPrettyPrint
+	}
+	return *opts.PrettyPrint
+}
+
+// handler is the active Handler every Logger method delegates to. It defaults to a JSONHandler
+// writing to logWriter, which reproduces noodlog's previous hardcoded JSON output.
+var handler Handler = NewJSONHandler(logWriter, nil)
+
+type jsonHandler struct {
+	writer io.Writer
+	opts   *HandlerOptions
+}
+
+// NewJSONHandler returns a Handler that writes each record as a single JSON object, honouring the
+// package's JSONPrettyPrint, Colors and ObscureSensitiveData settings.
+func NewJSONHandler(w io.Writer, opts *HandlerOptions) Handler {
+	return &jsonHandler{writer: w, opts: opts}
+}
+
+func (h *jsonHandler) Enabled(level string) bool {
+	return handlerLevelEnabled(level, h.opts)
+}
+
+func (h *jsonHandler) Handle(rec record) error {
+	var jsn []byte
+	var err error
+	if handlerPrettyPrintEnabled(h.opts) {
+		jsn, err = json.MarshalIndent(rec, "", "   ")
+	} else {
+		jsn, err = json.Marshal(rec)
+	}
+	if err != nil {
+		return err
+	}
+	out := string(jsn)
+	if handlerColorsEnabled(h.opts) {
+		out = fmt.Sprintf("%s%s%s", colorMap[rec.Level], out, colorReset)
+	}
+	_, err = fmt.Fprint(h.writer, out)
+	return err
+}
+
+type logfmtHandler struct {
+	writer io.Writer
+	opts   *HandlerOptions
+}
+
+// NewLogfmtHandler returns a Handler that writes each record as logfmt key=value pairs, one record
+// per line, which is easier to grep and to feed into logfmt-aware tooling than JSON.
+func NewLogfmtHandler(w io.Writer, opts *HandlerOptions) Handler {
+	return &logfmtHandler{writer: w, opts: opts}
+}
+
+func (h *logfmtHandler) Enabled(level string) bool {
+	return handlerLevelEnabled(level, h.opts)
+}
+
+func (h *logfmtHandler) Handle(rec record) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "time=%q level=%s message=%s", rec.Time, rec.Level, logfmtValue(rec.Message))
+	if rec.File != nil {
+		fmt.Fprintf(&b, " file=%q", *rec.File)
+	}
+	if rec.Function != nil {
+		fmt.Fprintf(&b, " function=%q", *rec.Function)
+	}
+	for _, key := range sortedFieldKeys(rec.Fields) {
+		fmt.Fprintf(&b, " %s=%s", key, logfmtValue(rec.Fields[key]))
+	}
+	b.WriteByte('\n')
+	_, err := fmt.Fprint(h.writer, b.String())
+	return err
+}
+
+// logfmtValue renders a record field as a quoted logfmt value, JSON-encoding non-string values so
+// nested objects and arrays stay on a single line.
+func logfmtValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	jsn, _ := json.Marshal(v)
+	return strconv.Quote(string(jsn))
+}
+
+type terminalHandler struct {
+	writer io.Writer
+	opts   *HandlerOptions
+	isTTY  bool
+}
+
+// NewTerminalHandler returns a Handler tuned for interactive use: when w is attached to a terminal
+// it prints one colored line per record with aligned time/level columns, and falls back to plain
+// text when it isn't, e.g. when stdout is redirected to a file.
+func NewTerminalHandler(w io.Writer, opts *HandlerOptions) Handler {
+	return &terminalHandler{writer: w, opts: opts, isTTY: isTerminal(w)}
+}
+
+func (h *terminalHandler) Enabled(level string) bool {
+	return handlerLevelEnabled(level, h.opts)
+}
+
+func (h *terminalHandler) Handle(rec record) error {
+	line := fmt.Sprintf("%-24s %-5s %v", rec.Time, strings.ToUpper(rec.Level), rec.Message)
+	for _, key := range sortedFieldKeys(rec.Fields) {
+		line += fmt.Sprintf(" %s=%v", key, rec.Fields[key])
+	}
+	colorize := h.isTTY
+	if h.opts != nil && h.opts.Colors != nil {
+		colorize = *h.opts.Colors
+	}
+	if colorize {
+		line = fmt.Sprintf("%s%s%s", colorMap[rec.Level], line, colorReset)
+	}
+	_, err := fmt.Fprintln(h.writer, line)
+	return err
+}
+
+// sortedFieldKeys returns fields' keys in sorted order so logfmtHandler and terminalHandler emit a
+// deterministic field order instead of Go's randomized map iteration order.
+func sortedFieldKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// multiHandler fans a record out to every inner Handler, so a Logger can e.g. write structured JSON
+// to a file and colored lines to a terminal at the same time instead of picking one.
+type multiHandler struct {
+	handlers []Handler
+}
+
+// NewMultiHandler returns a Handler that forwards every record to each of handlers in order,
+// continuing even if one returns an error, and joining every non-nil error it collects.
+func NewMultiHandler(handlers ...Handler) Handler {
+	return &multiHandler{handlers: handlers}
+}
+
+func (h *multiHandler) Enabled(level string) bool {
+	for _, inner := range h.handlers {
+		if inner.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *multiHandler) Handle(rec record) error {
+	var errs []error
+	for _, inner := range h.handlers {
+		if !inner.Enabled(rec.Level) {
+			continue
+		}
+		if err := inner.Handle(rec); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isTerminal reports whether w is a character device, i.e. an interactive terminal rather than a
+// redirected file or pipe.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,86 @@
+package noodlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLogfmtHandlerIncludesFields(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewLogfmtHandler(&buf, nil)
+
+	rec := record{Level: infoLabel, Message: "hello", Time: "now", Fields: map[string]interface{}{"request_id": "abc123"}}
+	if err := h.Handle(rec); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "request_id=") {
+		t.Fatalf("logfmt output %q does not include request_id field", buf.String())
+	}
+}
+
+func TestTerminalHandlerIncludesFields(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewTerminalHandler(&buf, nil)
+
+	rec := record{Level: infoLabel, Message: "hello", Time: "now", Fields: map[string]interface{}{"request_id": "abc123"}}
+	if err := h.Handle(rec); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "request_id=abc123") {
+		t.Fatalf("terminal output %q does not include request_id field", buf.String())
+	}
+}
+
+func TestJSONHandlerColorsDoNotLeakFromGlobalWhenDisabledInOptions(t *testing.T) {
+	prevColorEnabled := colorEnabled
+	colorEnabled = true
+	defer func() { colorEnabled = prevColorEnabled }()
+
+	disabled := false
+	var buf bytes.Buffer
+	h := NewJSONHandler(&buf, &HandlerOptions{Colors: &disabled})
+
+	rec := record{Level: infoLabel, Message: "hello", Time: "now"}
+	if err := h.Handle(rec); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		t.Fatalf("output %q is not valid JSON: global Colors leaked into a handler that opted out", buf.String())
+	}
+}
+
+func TestMultiHandlerFansOutToEveryHandler(t *testing.T) {
+	var jsonBuf, logfmtBuf bytes.Buffer
+	h := NewMultiHandler(NewJSONHandler(&jsonBuf, nil), NewLogfmtHandler(&logfmtBuf, nil))
+
+	rec := record{Level: infoLabel, Message: "hello", Time: "now"}
+	if err := h.Handle(rec); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if !json.Valid(jsonBuf.Bytes()) {
+		t.Fatalf("json output %q is not valid JSON", jsonBuf.String())
+	}
+	if !strings.Contains(logfmtBuf.String(), "message=") {
+		t.Fatalf("logfmt output %q missing message field", logfmtBuf.String())
+	}
+}
+
+func TestMultiHandlerEnabledIfAnyHandlerEnabled(t *testing.T) {
+	h := NewMultiHandler(
+		&levelOnlyHandler{level: errorLabel},
+		&levelOnlyHandler{level: debugLabel},
+	)
+
+	if !h.Enabled(debugLabel) {
+		t.Fatal("Enabled(debugLabel) = false, want true: one of the fanned-out handlers is enabled for debug")
+	}
+	if h.Enabled(warnLabel) {
+		t.Fatal("Enabled(warnLabel) = true, want false: neither fanned-out handler is enabled for warn")
+	}
+}
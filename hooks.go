@@ -0,0 +1,90 @@
+package noodlog
+
+// Record is the exported view of a log record passed to hooks, mirroring the internal record type
+// so hook implementations living in other packages (noodlog/hooks/syslog, noodlog/hooks/file, ...)
+// can read it.
+type Record struct {
+	Level    string
+	Message  interface{}
+	Time     string
+	File     *string
+	Function *string
+	Fields   map[string]interface{}
+}
+
+// Hook lets an external sink (Sentry, syslog, Kafka, a log file...) observe every record a Logger
+// emits, independently of whatever the active Handler does with it. This is how noodlog can print
+// colored output to stdout for developers while also shipping error-level events to a remote
+// aggregator in production.
+type Hook interface {
+	// Levels returns the labels this hook wants to receive.
+	Levels() []string
+	// Fire is called once per matching record, after it has been composed.
+	Fire(rec Record) error
+}
+
+var hooks []Hook
+
+// ErrorHandler receives errors that would otherwise be silently discarded, starting with errors
+// returned by Hook.Fire.
+type ErrorHandler func(error)
+
+var hookErrorHandler ErrorHandler = func(error) {}
+
+// AddHook registers a Hook to be invoked for every record at a level it declares interest in.
+func AddHook(hook Hook) {
+	hooks = append(hooks, hook)
+}
+
+// ClearHooks removes every registered Hook.
+func ClearHooks() {
+	hooks = nil
+}
+
+// SetErrorHandler overrides how hook errors are reported. Passing nil restores the default, which
+// discards them, matching noodlog's previous handling of json.Marshal errors.
+func SetErrorHandler(h ErrorHandler) {
+	if h == nil {
+		h = func(error) {}
+	}
+	hookErrorHandler = h
+}
+
+// hookInterested reports whether any registered hook wants records at label, so printLog can skip
+// composing a record when neither the handler nor any hook would see it.
+func hookInterested(label string) bool {
+	for _, hook := range hooks {
+		for _, level := range hook.Levels() {
+			if level == label {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fireHooks invokes every registered Hook interested in rec.Level, reporting any error to the
+// active ErrorHandler instead of discarding it.
+func fireHooks(rec record) {
+	if len(hooks) == 0 {
+		return
+	}
+	exported := Record{
+		Level:    rec.Level,
+		Message:  rec.Message,
+		Time:     rec.Time,
+		File:     rec.File,
+		Function: rec.Function,
+		Fields:   rec.Fields,
+	}
+	for _, hook := range hooks {
+		for _, level := range hook.Levels() {
+			if level == rec.Level {
+				if err := hook.Fire(exported); err != nil {
+					hookErrorHandler(err)
+				}
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Package file implements a noodlog.Hook that appends records to a log file, rotating it once it
+// crosses a size or age threshold and gzipping the rotated copy.
+package file
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/marcosansoni/noodlog"
+)
+
+// Hook appends records at the configured Levels to a file, rotating it per MaxSize/MaxAge.
+type Hook struct {
+	mu       sync.Mutex
+	path     string
+	levels   []string
+	maxSize  int64
+	maxAge   time.Duration
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// New opens (creating if needed) the file at path and returns a Hook that forwards records for
+// levels to it, rotating the file once it has reached maxSize bytes or has been open longer than
+// maxAge. A zero maxSize or maxAge disables that rotation trigger.
+func New(path string, levels []string, maxSize int64, maxAge time.Duration) (*Hook, error) {
+	h := &Hook{path: path, levels: levels, maxSize: maxSize, maxAge: maxAge}
+	if err := h.open(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Levels returns the levels this hook forwards.
+func (h *Hook) Levels() []string {
+	return h.levels
+}
+
+// Fire appends rec to the file, rotating first if the current file has crossed a threshold.
+func (h *Hook) Fire(rec noodlog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.shouldRotate() {
+		if err := h.rotate(); err != nil {
+			return err
+		}
+	}
+
+	line := fmt.Sprintf("%s\t%s\t%v\n", rec.Time, rec.Level, rec.Message)
+	n, err := h.file.WriteString(line)
+	h.size += int64(n)
+	return err
+}
+
+// Close flushes and closes the underlying file.
+func (h *Hook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.file.Close()
+}
+
+func (h *Hook) open() error {
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("file hook: open %s: %w", h.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("file hook: stat %s: %w", h.path, err)
+	}
+	h.file = f
+	h.size = info.Size()
+	h.openedAt = time.Now()
+	return nil
+}
+
+func (h *Hook) shouldRotate() bool {
+	if h.maxSize > 0 && h.size >= h.maxSize {
+		return true
+	}
+	if h.maxAge > 0 && time.Since(h.openedAt) >= h.maxAge {
+		return true
+	}
+	return false
+}
+
+func (h *Hook) rotate() error {
+	if err := h.file.Close(); err != nil {
+		return err
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", h.path, time.Now().Format("20060102150405.000000000"))
+	if err := os.Rename(h.path, rotatedPath); err != nil {
+		return fmt.Errorf("file hook: rotate %s: %w", h.path, err)
+	}
+	if err := gzipAndRemove(rotatedPath); err != nil {
+		return err
+	}
+
+	return h.open()
+}
+
+func gzipAndRemove(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("file hook: open rotated file %s: %w", path, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("file hook: create %s.gz: %w", path, err)
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		return fmt.Errorf("file hook: gzip %s: %w", path, err)
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
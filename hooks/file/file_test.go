@@ -0,0 +1,42 @@
+package file
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/marcosansoni/noodlog"
+)
+
+func TestHookRotationProducesDistinctGzipFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	h, err := New(path, []string{"info"}, 10, 0)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer h.Close()
+
+	rec := noodlog.Record{Level: "info", Message: "a message long enough to cross the tiny maxSize threshold"}
+	for i := 0; i < 5; i++ {
+		if err := h.Fire(rec); err != nil {
+			t.Fatalf("Fire() error = %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*.gz")
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(matches) < 2 {
+		t.Fatalf("got %d rotated .gz files, want at least 2 distinct ones: %v", len(matches), matches)
+	}
+
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		if seen[m] {
+			t.Fatalf("duplicate rotated file %s: a rotation overwrote an earlier one", m)
+		}
+		seen[m] = true
+	}
+}
@@ -0,0 +1,32 @@
+// Package multi implements a noodlog.Hook that fans a record out to several other hooks.
+package multi
+
+import "github.com/marcosansoni/noodlog"
+
+// Hook fans every record it receives out to each of its underlying hooks.
+type Hook struct {
+	hooks  []noodlog.Hook
+	levels []string
+}
+
+// New returns a Hook that forwards records for levels to every hook in hooks.
+func New(levels []string, hooks ...noodlog.Hook) *Hook {
+	return &Hook{hooks: hooks, levels: levels}
+}
+
+// Levels returns the levels this hook forwards.
+func (h *Hook) Levels() []string {
+	return h.levels
+}
+
+// Fire calls Fire on every underlying hook, returning the first error encountered after giving
+// every hook a chance to run.
+func (h *Hook) Fire(rec noodlog.Record) error {
+	var firstErr error
+	for _, hook := range h.hooks {
+		if err := hook.Fire(rec); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
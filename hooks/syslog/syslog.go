@@ -0,0 +1,71 @@
+// Package syslog implements a noodlog.Hook that forwards records to a syslog collector over
+// UDP, TCP or a unix socket, formatted per RFC 5424.
+package syslog
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/marcosansoni/noodlog"
+)
+
+// facility is the syslog facility used for every message; 16 is local0, a reasonable default for
+// an application that doesn't own a dedicated facility number.
+const facility = 16
+
+var severityByLevel = map[string]int{
+	"trace": 7,
+	"debug": 7,
+	"info":  6,
+	"warn":  4,
+	"error": 3,
+	"panic": 2,
+	"fatal": 2,
+}
+
+// Hook forwards records at the configured Levels to a syslog collector.
+type Hook struct {
+	conn    net.Conn
+	appName string
+	levels  []string
+}
+
+// New dials network (one of "udp", "tcp" or "unixgram") at addr and returns a Hook that forwards
+// records for levels to it. appName is included in the RFC 5424 header.
+func New(network, addr, appName string, levels []string) (*Hook, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("syslog: dial %s %s: %w", network, addr, err)
+	}
+	return &Hook{conn: conn, appName: appName, levels: levels}, nil
+}
+
+// Levels returns the levels this hook forwards.
+func (h *Hook) Levels() []string {
+	return h.levels
+}
+
+// Fire writes rec to the syslog connection as an RFC 5424 message.
+func (h *Hook) Fire(rec noodlog.Record) error {
+	severity := severityByLevel[rec.Level]
+	priority := facility*8 + severity
+	msg := fmt.Sprintf("<%d>1 %s %s %s - - - %v\n",
+		priority, time.Now().UTC().Format(time.RFC3339), hostname(), h.appName, rec.Message)
+	_, err := h.conn.Write([]byte(msg))
+	return err
+}
+
+// Close closes the underlying connection.
+func (h *Hook) Close() error {
+	return h.conn.Close()
+}
+
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return "localhost"
+	}
+	return name
+}
@@ -0,0 +1,103 @@
+package noodlog
+
+// Enabled reports whether a log call at level would currently be handled by the active Handler or
+// by any registered Hook, letting callers guard expensive message construction (e.g. serializing a
+// large struct) without duplicating that check themselves. It must stay in sync with printLog's own
+// gating: a bare comparison against the global logLevel would miss a Handler configured with its own
+// HandlerOptions.Level, or a Hook interested in a level the global logLevel filters out.
+func Enabled(level string) bool {
+	return handler.Enabled(level) || hookInterested(level)
+}
+
+// TraceFn prints a log with trace log level, invoking fn only if trace logging is enabled. Prefer
+// this over Trace when building the message is itself expensive.
+func TraceFn(fn func() interface{}) {
+	if !Enabled(traceLabel) {
+		return
+	}
+	printLog(traceLabel, []interface{}{fn()})
+}
+
+// DebugFn prints a log with debug log level, invoking fn only if debug logging is enabled.
+func DebugFn(fn func() interface{}) {
+	if !Enabled(debugLabel) {
+		return
+	}
+	printLog(debugLabel, []interface{}{fn()})
+}
+
+// InfoFn prints a log with info log level, invoking fn only if info logging is enabled.
+func InfoFn(fn func() interface{}) {
+	if !Enabled(infoLabel) {
+		return
+	}
+	printLog(infoLabel, []interface{}{fn()})
+}
+
+// WarnFn prints a log with warn log level, invoking fn only if warn logging is enabled.
+func WarnFn(fn func() interface{}) {
+	if !Enabled(warnLabel) {
+		return
+	}
+	printLog(warnLabel, []interface{}{fn()})
+}
+
+// ErrorFn prints a log with error log level, invoking fn only if error logging is enabled.
+func ErrorFn(fn func() interface{}) {
+	if !Enabled(errorLabel) {
+		return
+	}
+	printLog(errorLabel, []interface{}{fn()})
+}
+
+// Enabled reports whether a log call at level would currently be handled by the active Handler or
+// by any registered Hook. l's accumulated fields don't affect level gating, so this matches the
+// package-level Enabled.
+func (l *Logger) Enabled(level string) bool {
+	return handler.Enabled(level) || hookInterested(level)
+}
+
+// TraceFn prints a log with trace log level, merging in l's fields, invoking fn only if trace
+// logging is enabled.
+func (l *Logger) TraceFn(fn func() interface{}) {
+	if !l.Enabled(traceLabel) {
+		return
+	}
+	l.printLog(traceLabel, []interface{}{fn()})
+}
+
+// DebugFn prints a log with debug log level, merging in l's fields, invoking fn only if debug
+// logging is enabled.
+func (l *Logger) DebugFn(fn func() interface{}) {
+	if !l.Enabled(debugLabel) {
+		return
+	}
+	l.printLog(debugLabel, []interface{}{fn()})
+}
+
+// InfoFn prints a log with info log level, merging in l's fields, invoking fn only if info logging
+// is enabled.
+func (l *Logger) InfoFn(fn func() interface{}) {
+	if !l.Enabled(infoLabel) {
+		return
+	}
+	l.printLog(infoLabel, []interface{}{fn()})
+}
+
+// WarnFn prints a log with warn log level, merging in l's fields, invoking fn only if warn logging
+// is enabled.
+func (l *Logger) WarnFn(fn func() interface{}) {
+	if !l.Enabled(warnLabel) {
+		return
+	}
+	l.printLog(warnLabel, []interface{}{fn()})
+}
+
+// ErrorFn prints a log with error log level, merging in l's fields, invoking fn only if error
+// logging is enabled.
+func (l *Logger) ErrorFn(fn func() interface{}) {
+	if !l.Enabled(errorLabel) {
+		return
+	}
+	l.printLog(errorLabel, []interface{}{fn()})
+}
@@ -0,0 +1,30 @@
+package noodlog
+
+import "testing"
+
+type levelOnlyHandler struct{ level string }
+
+func (h *levelOnlyHandler) Enabled(level string) bool { return level == h.level }
+func (h *levelOnlyHandler) Handle(record) error       { return nil }
+
+type fixedHook struct{ levels []string }
+
+func (h *fixedHook) Levels() []string  { return h.levels }
+func (h *fixedHook) Fire(Record) error { return nil }
+
+func TestEnabledConsidersHooksIndependentlyOfHandler(t *testing.T) {
+	prevHandler := handler
+	prevHooks := hooks
+	defer func() {
+		handler = prevHandler
+		hooks = prevHooks
+	}()
+
+	handler = &levelOnlyHandler{level: errorLabel}
+	ClearHooks()
+	AddHook(&fixedHook{levels: []string{debugLabel}})
+
+	if !Enabled(debugLabel) {
+		t.Fatal("Enabled(debugLabel) = false, want true: a hook registered for debug should make it enabled regardless of the handler's own level")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"regexp"
 	"strings"
 	"time"
 )
@@ -22,14 +21,29 @@ var JSONPrettyPrint bool = false
 // obscureSensitiveDataEnabled represents the sensitive data obscuration flag
 var obscureSensitiveDataEnabled bool = false
 
-var logLevels = map[string]int{
-	traceLabel: traceLevel,
-	debugLabel: debugLevel,
-	infoLabel:  infoLevel,
-	warnLabel:  warnLevel,
-	errorLabel: errorLevel,
-	panicLabel: panicLevel,
-	fatalLabel: fatalLevel,
+// labelLevel resolves a level label to its integer level via a switch instead of a map lookup, so
+// the level check on the hot path (handlerLevelEnabled, LogLevel) compiles down to a single integer
+// comparison rather than a map access. An unrecognized label resolves to infoLevel, matching
+// LogLevel's previous fallback.
+func labelLevel(label string) int {
+	switch label {
+	case traceLabel:
+		return traceLevel
+	case debugLabel:
+		return debugLevel
+	case infoLabel:
+		return infoLevel
+	case warnLabel:
+		return warnLevel
+	case errorLabel:
+		return errorLevel
+	case panicLabel:
+		return panicLevel
+	case fatalLabel:
+		return fatalLevel
+	default:
+		return infoLevel
+	}
 }
 
 var sensitiveParams = []string{}
@@ -64,21 +78,42 @@ func SetConfigs(configs Configs) {
 	if configs.SensitiveParams != nil {
 		SetSensitiveParams(configs.SensitiveParams)
 	}
+	if configs.SensitiveParamsCaseInsensitive != nil {
+		SetSensitiveParamsCaseInsensitive(*configs.SensitiveParamsCaseInsensitive)
+	}
+	if configs.MaskFunc != nil {
+		SetMaskFunc(configs.MaskFunc)
+	}
+	if configs.ErrorHandler != nil {
+		SetErrorHandler(configs.ErrorHandler)
+	}
+	if configs.Handler != nil {
+		handler = configs.Handler
+	}
+	if configs.AsyncBufferSize != nil {
+		flushInterval := time.Second
+		if configs.FlushInterval != nil {
+			flushInterval = *configs.FlushInterval
+		}
+		overflow := Block
+		if configs.OverflowPolicy != nil {
+			overflow = *configs.OverflowPolicy
+		}
+		handler = newAsyncHandler(handler, *configs.AsyncBufferSize, flushInterval, overflow)
+	}
 
 }
 
 // LogLevel function sets the log level
 func LogLevel(level string) {
-	logLevel = logLevels[level]
-	if logLevel == 0 {
-		logLevel = infoLevel
-	}
+	logLevel = labelLevel(level)
 }
 
 // LogWriter function sets the new writer
 // TODO: if handle is a file, disable color and indentation ?
 func LogWriter(w io.Writer) {
 	logWriter = w
+	handler = NewJSONHandler(w, nil)
 }
 
 // EnableJSONPrettyPrint enables JSON pretty printing
@@ -134,23 +169,36 @@ func Error(message ...interface{}) {
 
 // Panic function prints a log with panic log level
 func Panic(message ...interface{}) {
-	panic(composeLog(panicLabel, message))
+	rec := newRecord(panicLabel, message)
+	fireHooks(rec)
+	logRecord := formatRecord(rec)
+	Flush()
+	panic(logRecord)
 }
 
 // Fatal function prints a log with fatal log level
 func Fatal(message ...interface{}) {
 	printLog(fatalLabel, message)
+	Flush()
 	os.Exit(1)
 }
 
 func printLog(label string, message []interface{}) {
-	if logLevels[label] >= logLevel {
-		fmt.Fprintf(logWriter, composeLog(label, message))
+	handlerOK := handler.Enabled(label)
+	if !handlerOK && !hookInterested(label) {
+		return
 	}
-}
 
-func composeLog(level string, message []interface{}) string {
+	rec := newRecord(label, message)
+	fireHooks(rec)
+	if handlerOK {
+		_ = handler.Handle(rec)
+	}
+}
 
+// newRecord builds the record for a log call, shared by printLog (which hands it off to the active
+// Handler) and Panic (which fires hooks and formats its panic value directly).
+func newRecord(level string, message []interface{}) record {
 	logMsg := record{
 		Level:   level,
 		Message: composeMessage(message),
@@ -163,6 +211,13 @@ func composeLog(level string, message []interface{}) string {
 		logMsg.Function = &fx
 	}
 
+	return logMsg
+}
+
+// formatRecord renders rec the way Panic needs it: as a JSON string, colored when colorEnabled is
+// set. It backs Panic and Logger.Panic, which both build a panic value directly rather than going
+// through a Handler.
+func formatRecord(logMsg record) string {
 	var jsn []byte
 	if JSONPrettyPrint {
 		jsn, _ = json.MarshalIndent(logMsg, "", "   ")
@@ -172,7 +227,7 @@ func composeLog(level string, message []interface{}) string {
 
 	logRecord := string(jsn)
 	if colorEnabled {
-		logRecord = fmt.Sprintf("%s%s%s", colorMap[level], logRecord, colorReset)
+		logRecord = fmt.Sprintf("%s%s%s", colorMap[logMsg.Level], logRecord, colorReset)
 	}
 
 	return logRecord
@@ -211,15 +266,17 @@ func adaptMessage(message interface{}) interface{} {
 	switch message.(type) {
 	case string:
 		strMsg := message.(string)
+		obj := strToObj(strMsg)
 		if obscureSensitiveDataEnabled && len(sensitiveParams) != 0 {
-			return strToObj(obscureSensitiveData(strMsg))
+			return obscureSensitiveData(obj)
 		}
-		return strToObj(strMsg)
+		return obj
 	default:
 		if obscureSensitiveDataEnabled && len(sensitiveParams) != 0 {
 			jsn, _ := json.Marshal(message)
-			strMsg := obscureSensitiveData(string(jsn))
-			return strToObj(strMsg)
+			var obj interface{}
+			_ = json.Unmarshal(jsn, &obj)
+			return obscureSensitiveData(obj)
 		}
 	}
 	return message
@@ -233,18 +290,3 @@ func strToObj(strMsg string) interface{} {
 	}
 	return strMsg
 }
-
-func obscureSensitiveData(jsn string) string {
-	for _, param := range sensitiveParams {
-		jsn = obscureParam(jsn, param)
-	}
-	return jsn
-}
-
-func obscureParam(jsn string, param string) string {
-	rWithSlash := *regexp.MustCompile(`\\"` + param + `\\":.*?"(.*?)\\"`)
-	jsn = rWithSlash.ReplaceAllString(jsn, `\"`+param+`\": \"**********\"`)
-
-	rWithoutSlash := *regexp.MustCompile(`"` + param + `":.*?"(.*?)"`)
-	return rWithoutSlash.ReplaceAllString(jsn, `"`+param+`": "**********"`)
-}
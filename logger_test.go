@@ -0,0 +1,19 @@
+package noodlog
+
+import "testing"
+
+func TestLabelLevelUnknownLabelDefaultsToInfo(t *testing.T) {
+	if labelLevel("bogus") != infoLevel {
+		t.Fatalf("labelLevel(%q) = %d, want infoLevel", "bogus", labelLevel("bogus"))
+	}
+}
+
+func TestLogLevelUnknownLevelDefaultsToInfo(t *testing.T) {
+	prev := logLevel
+	defer func() { logLevel = prev }()
+
+	LogLevel("bogus")
+	if logLevel != infoLevel {
+		t.Fatalf("LogLevel(%q) set logLevel = %d, want infoLevel", "bogus", logLevel)
+	}
+}
@@ -0,0 +1,128 @@
+package noodlog
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MaskFunc customizes how a matched sensitive field is masked. It receives the matched key and its
+// original value and returns the value to emit in its place, e.g. to show only the last 4 digits
+// of a credit card instead of replacing it outright.
+type MaskFunc func(key string, val interface{}) interface{}
+
+// maskFunc is the active MaskFunc. It defaults to defaultMaskFunc, which replaces the value
+// outright, keeping the JSON type of strings, booleans and numbers.
+var maskFunc MaskFunc = defaultMaskFunc
+
+// sensitiveParamsCaseInsensitive makes sensitive param matching case-insensitive when true.
+var sensitiveParamsCaseInsensitive bool = false
+
+// SetMaskFunc overrides how matched sensitive fields are masked. Passing nil restores the default,
+// which replaces booleans with false, numbers with 0, and strings and every other value (objects,
+// arrays, null) with "**********".
+func SetMaskFunc(f MaskFunc) {
+	if f == nil {
+		f = defaultMaskFunc
+	}
+	maskFunc = f
+}
+
+// SetSensitiveParamsCaseInsensitive controls whether sensitive param matching ignores case.
+func SetSensitiveParamsCaseInsensitive(caseInsensitive bool) {
+	sensitiveParamsCaseInsensitive = caseInsensitive
+}
+
+func defaultMaskFunc(_ string, val interface{}) interface{} {
+	switch val.(type) {
+	case string:
+		return "**********"
+	case bool:
+		return false
+	case float64:
+		return 0
+	default:
+		return "**********"
+	}
+}
+
+// obscureSensitiveData walks message structurally, masking any field whose key (or dotted path)
+// matches a configured sensitive param. This replaces the previous two-regex-per-field scan of the
+// marshaled JSON string, which broke on nested objects, arrays of objects, non-string values and
+// escaped quotes inside values.
+func obscureSensitiveData(message interface{}) interface{} {
+	return maskWalk(message, "")
+}
+
+func maskWalk(node interface{}, path string) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			if matchesSensitiveParam(k, fieldPath) {
+				out[k] = maskFunc(k, val)
+				continue
+			}
+			out[k] = maskWalk(val, fieldPath)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = maskWalk(val, path)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// regexpParamPrefix marks a sensitive param as a regular expression rather than a literal name,
+// dotted path, or glob, e.g. "re:^user\\.(ssn|tin)$". Without this prefix, a param like "user.ssn"
+// is matched literally; treating its "." as "match any character" would silently mask unrelated
+// fields such as "userXssn".
+const regexpParamPrefix = "re:"
+
+// matchesSensitiveParam reports whether key or its dotted path from the message root matches a
+// configured sensitive param. Params can be a plain key name, a dotted path (user.ssn), a glob
+// pattern (*.password), or, prefixed with "re:", an arbitrary regular expression.
+func matchesSensitiveParam(key, path string) bool {
+	for _, param := range sensitiveParams {
+		if pattern, ok := strings.CutPrefix(param, regexpParamPrefix); ok {
+			if matchesRegexpParam(pattern, key, path) {
+				return true
+			}
+			continue
+		}
+
+		candidateKey, candidatePath, target := key, path, param
+		if sensitiveParamsCaseInsensitive {
+			candidateKey = strings.ToLower(candidateKey)
+			candidatePath = strings.ToLower(candidatePath)
+			target = strings.ToLower(target)
+		}
+
+		if candidateKey == target || candidatePath == target {
+			return true
+		}
+		if ok, _ := filepath.Match(target, candidatePath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRegexpParam(pattern, key, path string) bool {
+	if sensitiveParamsCaseInsensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(key) || re.MatchString(path)
+}
@@ -0,0 +1,103 @@
+package noodlog
+
+import "testing"
+
+func withSensitiveParams(t *testing.T, params []string) {
+	t.Helper()
+	prev := sensitiveParams
+	sensitiveParams = params
+	t.Cleanup(func() { sensitiveParams = prev })
+}
+
+func TestMatchesSensitiveParamLiteralDottedPathDoesNotActAsRegexp(t *testing.T) {
+	withSensitiveParams(t, []string{"user.ssn"})
+
+	if matchesSensitiveParam("ssn", "userXssn") {
+		t.Fatal(`"user.ssn" matched "userXssn": literal dotted-path params must not be treated as regexps`)
+	}
+	if !matchesSensitiveParam("ssn", "user.ssn") {
+		t.Fatal(`"user.ssn" should match the literal path "user.ssn"`)
+	}
+}
+
+func TestMatchesSensitiveParamGlob(t *testing.T) {
+	withSensitiveParams(t, []string{"*.password"})
+
+	if !matchesSensitiveParam("password", "user.password") {
+		t.Fatal(`"*.password" should match "user.password"`)
+	}
+	if matchesSensitiveParam("password", "password") {
+		t.Fatal(`"*.password" should not match the bare top-level path "password"`)
+	}
+}
+
+func TestMatchesSensitiveParamExplicitRegexp(t *testing.T) {
+	withSensitiveParams(t, []string{"re:^user\\.(ssn|tin)$"})
+
+	if !matchesSensitiveParam("ssn", "user.ssn") {
+		t.Fatal(`"re:^user\.(ssn|tin)$" should match "user.ssn"`)
+	}
+	if matchesSensitiveParam("ssn", "userXssn") {
+		t.Fatal(`"re:^user\.(ssn|tin)$" should not match "userXssn"`)
+	}
+}
+
+func TestObscureSensitiveDataWalksNestedStructures(t *testing.T) {
+	withSensitiveParams(t, []string{"password"})
+
+	in := map[string]interface{}{
+		"user": map[string]interface{}{
+			"password": "hunter2",
+		},
+		"accounts": []interface{}{
+			map[string]interface{}{"password": "swordfish"},
+		},
+	}
+
+	got := obscureSensitiveData(in)
+	out, ok := got.(map[string]interface{})
+	if !ok {
+		t.Fatalf("obscureSensitiveData returned %T, want map[string]interface{}", got)
+	}
+	user := out["user"].(map[string]interface{})
+	if user["password"] != "**********" {
+		t.Fatalf("nested password = %v, want masked", user["password"])
+	}
+	accounts := out["accounts"].([]interface{})
+	account := accounts[0].(map[string]interface{})
+	if account["password"] != "**********" {
+		t.Fatalf("password in array element = %v, want masked", account["password"])
+	}
+}
+
+func benchmarkPayload() map[string]interface{} {
+	accounts := make([]interface{}, 50)
+	for i := range accounts {
+		accounts[i] = map[string]interface{}{
+			"id":       i,
+			"password": "hunter2",
+			"card": map[string]interface{}{
+				"number": "4111111111111111",
+				"active": true,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":     "jane",
+			"password": "hunter2",
+		},
+		"accounts": accounts,
+	}
+}
+
+func BenchmarkObscureSensitiveData(b *testing.B) {
+	sensitiveParams = []string{"password", "number"}
+	defer func() { sensitiveParams = nil }()
+
+	payload := benchmarkPayload()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		obscureSensitiveData(payload)
+	}
+}